@@ -0,0 +1,149 @@
+// Package cache provides an in-memory LRU cache in front of a parcel.Store,
+// for hot-read workloads such as a customer repeatedly polling one parcel.
+package cache
+
+import (
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+)
+
+// CachedParcelStore wraps a parcel.Store and serves Get/GetByClient from an
+// LRU cache, invalidating affected entries on every write.
+type CachedParcelStore struct {
+	inner parcel.Store
+
+	byNumber *lru.Cache[int, parcel.Parcel]
+	byClient *lru.Cache[int, []parcel.Parcel]
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	// generation counts writes. A Get/GetByClient that misses the cache
+	// snapshots generation before reading inner and only caches the result
+	// if generation hasn't moved while the read was in flight — otherwise a
+	// concurrent write could have invalidated the entry before the stale
+	// read was inserted, caching it forever.
+	generation atomic.Uint64
+}
+
+// NewCachedParcelStore wraps inner with an LRU cache holding up to size
+// entries per lookup kind (by number, by client).
+func NewCachedParcelStore(inner parcel.Store, size int) *CachedParcelStore {
+	byNumber, err := lru.New[int, parcel.Parcel](size)
+	if err != nil {
+		panic(err)
+	}
+	byClient, err := lru.New[int, []parcel.Parcel](size)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CachedParcelStore{
+		inner:    inner,
+		byNumber: byNumber,
+		byClient: byClient,
+	}
+}
+
+// Hits returns the number of cache hits served so far.
+func (c *CachedParcelStore) Hits() uint64 { return c.hits.Load() }
+
+// Misses returns the number of cache misses served so far.
+func (c *CachedParcelStore) Misses() uint64 { return c.misses.Load() }
+
+func (c *CachedParcelStore) Add(p parcel.Parcel) (int, error) {
+	number, err := c.inner.Add(p)
+	if err != nil {
+		return 0, err
+	}
+
+	c.byClient.Remove(p.Client)
+	c.generation.Add(1)
+
+	return number, nil
+}
+
+func (c *CachedParcelStore) Get(number int) (parcel.Parcel, error) {
+	if p, ok := c.byNumber.Get(number); ok {
+		c.hits.Add(1)
+		return p, nil
+	}
+	c.misses.Add(1)
+
+	gen := c.generation.Load()
+
+	p, err := c.inner.Get(number)
+	if err != nil {
+		return parcel.Parcel{}, err
+	}
+
+	if c.generation.Load() == gen {
+		c.byNumber.Add(number, p)
+	}
+
+	return p, nil
+}
+
+func (c *CachedParcelStore) GetByClient(client int) ([]parcel.Parcel, error) {
+	if parcels, ok := c.byClient.Get(client); ok {
+		c.hits.Add(1)
+		return parcels, nil
+	}
+	c.misses.Add(1)
+
+	gen := c.generation.Load()
+
+	parcels, err := c.inner.GetByClient(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.generation.Load() == gen {
+		c.byClient.Add(client, parcels)
+	}
+
+	return parcels, nil
+}
+
+func (c *CachedParcelStore) SetStatus(number int, status parcel.ParcelStatus) error {
+	if err := c.inner.SetStatus(number, status); err != nil {
+		return err
+	}
+
+	c.invalidate(number)
+
+	return nil
+}
+
+func (c *CachedParcelStore) SetAddress(number int, address string) error {
+	if err := c.inner.SetAddress(number, address); err != nil {
+		return err
+	}
+
+	c.invalidate(number)
+
+	return nil
+}
+
+func (c *CachedParcelStore) Delete(number int) error {
+	if err := c.inner.Delete(number); err != nil {
+		return err
+	}
+
+	c.invalidate(number)
+
+	return nil
+}
+
+// invalidate drops number from the by-number cache and clears the by-client
+// cache, since we don't track which client entry a number belongs to.
+func (c *CachedParcelStore) invalidate(number int) {
+	c.byNumber.Remove(number)
+	c.byClient.Purge()
+	c.generation.Add(1)
+}
+
+var _ parcel.Store = (*CachedParcelStore)(nil)
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+)
+
+// fakeStore is a minimal in-memory parcel.Store used to exercise the cache
+// in isolation from SQLite.
+type fakeStore struct {
+	mu      sync.Mutex
+	parcels map[int]parcel.Parcel
+	nextID  int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{parcels: map[int]parcel.Parcel{}}
+}
+
+func (s *fakeStore) Add(p parcel.Parcel) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	p.Number = s.nextID
+	s.parcels[p.Number] = p
+
+	return p.Number, nil
+}
+
+func (s *fakeStore) Get(number int) (parcel.Parcel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.parcels[number], nil
+}
+
+func (s *fakeStore) GetByClient(client int) ([]parcel.Parcel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []parcel.Parcel
+	for _, p := range s.parcels {
+		if p.Client == client {
+			res = append(res, p)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *fakeStore) SetStatus(number int, status parcel.ParcelStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.parcels[number]
+	p.Status = status
+	s.parcels[number] = p
+
+	return nil
+}
+
+func (s *fakeStore) SetAddress(number int, address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.parcels[number]
+	p.Address = address
+	s.parcels[number] = p
+
+	return nil
+}
+
+func (s *fakeStore) Delete(number int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.parcels, number)
+
+	return nil
+}
+
+// delayedGetStore wraps a fakeStore and sleeps partway through every Get, so
+// a test can force a write to land between the read and the cache insert.
+type delayedGetStore struct {
+	*fakeStore
+	delay time.Duration
+}
+
+func (s *delayedGetStore) Get(number int) (parcel.Parcel, error) {
+	time.Sleep(s.delay)
+	return s.fakeStore.Get(number)
+}
+
+func TestCachedParcelStore_GetDoesNotCacheStaleReadRacingWithWrite(t *testing.T) {
+	t.Parallel()
+
+	inner := &delayedGetStore{fakeStore: newFakeStore(), delay: 20 * time.Millisecond}
+	store := NewCachedParcelStore(inner, 10)
+
+	number, err := store.Add(parcel.Parcel{Client: 1, Status: parcel.ParcelStatusRegistered, Address: "a"})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		// Misses the empty cache and reads the old status from inner, but
+		// won't finish reading until after the write below completes.
+		_, _ = store.Get(number)
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(5 * time.Millisecond)
+		_ = store.SetStatus(number, parcel.ParcelStatusSent)
+	}()
+
+	wg.Wait()
+
+	p, err := store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, parcel.ParcelStatusSent, p.Status, "cache must not serve a status read that raced a concurrent write")
+}
+
+func TestCachedParcelStore_HitsAndMisses(t *testing.T) {
+	t.Parallel()
+
+	inner := newFakeStore()
+	store := NewCachedParcelStore(inner, 10)
+
+	number, err := store.Add(parcel.Parcel{Client: 1, Address: "a"})
+	require.NoError(t, err)
+
+	_, err = store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), store.Misses())
+	assert.Equal(t, uint64(0), store.Hits())
+
+	_, err = store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), store.Misses())
+	assert.Equal(t, uint64(1), store.Hits())
+}
+
+func TestCachedParcelStore_InvalidatesOnWrite(t *testing.T) {
+	t.Parallel()
+
+	inner := newFakeStore()
+	store := NewCachedParcelStore(inner, 10)
+
+	number, err := store.Add(parcel.Parcel{Client: 1, Address: "a"})
+	require.NoError(t, err)
+
+	p, err := store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, "a", p.Address)
+
+	require.NoError(t, store.SetAddress(number, "b"))
+
+	p, err = store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, "b", p.Address, "cache must not serve a stale address after SetAddress")
+}
+
+func TestCachedParcelStore_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	inner := newFakeStore()
+	store := NewCachedParcelStore(inner, 10)
+
+	number, err := store.Add(parcel.Parcel{Client: 1, Address: "a"})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = store.Get(number)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			_ = store.SetStatus(number, parcel.ParcelStatusSent)
+		}(i)
+	}
+	wg.Wait()
+
+	p, err := store.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, parcel.ParcelStatusSent, p.Status)
+}
@@ -0,0 +1,45 @@
+// Command server runs the parcel tracker as an HTTP service.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+	"github.com/creamlaflare/go-db-sql-final/server"
+
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dbPath := flag.String("db", "tracker.db", "path to the SQLite database file")
+	flag.Parse()
+
+	db, err := parcel.Open(*dbPath)
+	if err != nil {
+		log.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := parcel.Migrate(db); err != nil {
+		log.Fatalf("migrate db: %v", err)
+	}
+
+	store := parcel.NewParcelStore(db)
+	srv, err := server.New(*addr, store)
+	if err != nil {
+		log.Fatalf("listen: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("listening on %s", srv.Addr())
+	if err := srv.Run(ctx); err != nil {
+		log.Fatalf("server: %v", err)
+	}
+}
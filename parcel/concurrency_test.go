@@ -0,0 +1,56 @@
+package parcel_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+	"github.com/creamlaflare/go-db-sql-final/testhelpers"
+)
+
+// TestConcurrentWritersDoNotHitBusy spawns many goroutines writing to the
+// same database file concurrently and asserts none of them fail with
+// SQLITE_BUSY/SQLITE_LOCKED, relying on the busy_timeout pragma and the
+// retry-on-busy wrapper in withRetry.
+func TestConcurrentWritersDoNotHitBusy(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
+
+	const writers = 20
+
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			p := parcel.Parcel{
+				Client:    i,
+				Status:    parcel.ParcelStatusRegistered,
+				Address:   "concurrent",
+				CreatedAt: time.Now().UTC().Truncate(time.Second),
+			}
+
+			id, err := store.Add(p)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			errs[i] = store.SetStatus(id, parcel.ParcelStatusSent)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		require.NoError(t, err, "writer %d", i)
+	}
+}
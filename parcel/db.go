@@ -0,0 +1,12 @@
+package parcel
+
+import "database/sql"
+
+// Open opens the SQLite database at path, configured for a reasonable
+// amount of write concurrency: a busy timeout so concurrent writers wait
+// instead of failing immediately, WAL journaling so readers don't block
+// writers, and foreign keys enabled.
+func Open(path string) (*sql.DB, error) {
+	dsn := path + "?_pragma=busy_timeout(2000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	return sql.Open("sqlite", dsn)
+}
@@ -0,0 +1,73 @@
+package parcel_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+	"github.com/creamlaflare/go-db-sql-final/testhelpers"
+)
+
+func TestParcelJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	p := parcel.Parcel{
+		Number:    1,
+		Client:    1000,
+		Status:    parcel.ParcelStatusSent,
+		Address:   "test",
+		CreatedAt: time.Date(2024, 3, 14, 15, 9, 26, 0, time.FixedZone("", -7*3600)),
+	}
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number":1,"client":1000,"status":"sent","address":"test","created_at":"2024-03-14 15:09:26 -0700"}`, string(data))
+
+	var got parcel.Parcel
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.True(t, p.CreatedAt.Equal(got.CreatedAt))
+	got.CreatedAt = p.CreatedAt
+	assert.Equal(t, p, got)
+}
+
+func TestParcelStatusJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	statuses := []parcel.ParcelStatus{parcel.ParcelStatusRegistered, parcel.ParcelStatusSent, parcel.ParcelStatusDelivered}
+	for _, status := range statuses {
+		data, err := json.Marshal(status)
+		require.NoError(t, err)
+
+		var got parcel.ParcelStatus
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, status, got)
+	}
+}
+
+func TestParcelDBRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
+
+	p := parcel.Parcel{
+		Client:    2000,
+		Status:    parcel.ParcelStatusDelivered,
+		Address:   "db round trip",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	number, err := store.Add(p)
+	require.NoError(t, err)
+
+	got, err := store.Get(number)
+	require.NoError(t, err)
+
+	p.Number = number
+	assert.Equal(t, p, got)
+}
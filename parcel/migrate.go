@@ -0,0 +1,23 @@
+package parcel
+
+import "database/sql"
+
+// schema creates the tables the parcel package needs. It is safe to call
+// against an already-migrated database.
+const schema = `
+CREATE TABLE IF NOT EXISTS parcel (
+	number     INTEGER PRIMARY KEY AUTOINCREMENT,
+	client     INTEGER NOT NULL,
+	status     INTEGER NOT NULL,
+	address    TEXT    NOT NULL,
+	created_at TEXT    NOT NULL
+);
+`
+
+// Migrate brings db up to the schema ParcelStore expects. Tests and the
+// server binary call it instead of assuming the schema already exists on
+// disk.
+func Migrate(db *sql.DB) error {
+	_, err := db.Exec(schema)
+	return err
+}
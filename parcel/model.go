@@ -0,0 +1,64 @@
+// Package parcel implements the parcel tracker's domain model and storage
+// layer on top of SQLite.
+package parcel
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// createdAtJSONLayout is the stable, human-readable format Parcel.CreatedAt
+// is rendered as over JSON. The database always stores RFC3339.
+const createdAtJSONLayout = "2006-01-02 15:04:05 -0700"
+
+// Parcel represents a single tracked shipment.
+type Parcel struct {
+	Number    int
+	Client    int
+	Status    ParcelStatus
+	Address   string
+	CreatedAt time.Time
+}
+
+// parcelJSON mirrors Parcel's JSON shape, letting CreatedAt use a format
+// that doesn't depend on Go's default time.Time encoding.
+type parcelJSON struct {
+	Number    int          `json:"number"`
+	Client    int          `json:"client"`
+	Status    ParcelStatus `json:"status"`
+	Address   string       `json:"address"`
+	CreatedAt string       `json:"created_at"`
+}
+
+// MarshalJSON renders CreatedAt using createdAtJSONLayout instead of Go's
+// default RFC3339Nano encoding.
+func (p Parcel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(parcelJSON{
+		Number:    p.Number,
+		Client:    p.Client,
+		Status:    p.Status,
+		Address:   p.Address,
+		CreatedAt: p.CreatedAt.Format(createdAtJSONLayout),
+	})
+}
+
+// UnmarshalJSON parses CreatedAt using createdAtJSONLayout.
+func (p *Parcel) UnmarshalJSON(data []byte) error {
+	var aux parcelJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	createdAt, err := time.Parse(createdAtJSONLayout, aux.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	p.Number = aux.Number
+	p.Client = aux.Client
+	p.Status = aux.Status
+	p.Address = aux.Address
+	p.CreatedAt = createdAt
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package parcel
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	retryAttempts   = 5
+	retryBaseDelay  = 10 * time.Millisecond
+	retryMaxBackoff = 4 // doubles retryBaseDelay this many times: 10, 20, 40, 80, 160ms
+)
+
+// withRetry runs op, retrying with exponential backoff when SQLite reports
+// the database as busy or locked. Even with a busy_timeout pragma set,
+// concurrent writers can still observe SQLITE_BUSY/SQLITE_LOCKED under
+// contention, so writes get one more layer of resilience here.
+func withRetry(op func() error) error {
+	var err error
+	delay := retryBaseDelay
+
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		err = op()
+		if err == nil || !isBusyErr(err) {
+			return err
+		}
+
+		time.Sleep(delay)
+		if attempt < retryMaxBackoff {
+			delay *= 2
+		}
+	}
+
+	return err
+}
+
+// isBusyErr reports whether err looks like SQLITE_BUSY or SQLITE_LOCKED.
+// Driver error types vary, so this falls back to matching on the message.
+func isBusyErr(err error) bool {
+	var coder interface{ Code() int }
+	if errors.As(err, &coder) {
+		const (
+			sqliteBusy   = 5
+			sqliteLocked = 6
+		)
+		switch coder.Code() {
+		case sqliteBusy, sqliteLocked:
+			return true
+		}
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "SQLITE_BUSY") ||
+		strings.Contains(msg, "SQLITE_LOCKED") ||
+		strings.Contains(msg, "database is locked")
+}
@@ -0,0 +1,59 @@
+//go:build sqlcipher
+
+package parcel
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "tracker.db")
+	encryptedPath := filepath.Join(dir, "tracker.db.enc")
+	roundTrippedPath := filepath.Join(dir, "tracker.roundtrip.db")
+
+	plainDB, err := Open(plainPath)
+	require.NoError(t, err)
+	require.NoError(t, Migrate(plainDB))
+
+	store := NewParcelStore(plainDB)
+	p := Parcel{
+		Client:    7,
+		Status:    ParcelStatusRegistered,
+		Address:   "encrypted test",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	number, err := store.Add(p)
+	require.NoError(t, err)
+	require.NoError(t, plainDB.Close())
+
+	const passphrase = "test-passphrase"
+
+	require.NoError(t, EncryptExisting(plainPath, encryptedPath, passphrase, ReducedKDFIterations))
+
+	encryptedDB, err := OpenEncrypted(encryptedPath, passphrase, ReducedKDFIterations)
+	require.NoError(t, err)
+	encryptedStore := NewParcelStore(encryptedDB)
+	got, err := encryptedStore.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, p.Address, got.Address)
+	require.NoError(t, encryptedDB.Close())
+
+	require.NoError(t, DecryptExisting(encryptedPath, roundTrippedPath, passphrase, ReducedKDFIterations))
+
+	roundTrippedDB, err := Open(roundTrippedPath)
+	require.NoError(t, err)
+	defer roundTrippedDB.Close()
+
+	roundTrippedStore := NewParcelStore(roundTrippedDB)
+	got, err = roundTrippedStore.Get(number)
+	require.NoError(t, err)
+	assert.Equal(t, p.Address, got.Address)
+}
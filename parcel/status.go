@@ -0,0 +1,68 @@
+package parcel
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ParcelStatus is the lifecycle stage of a parcel. It's stored in SQLite as
+// a small integer but represented everywhere else as a stable string token,
+// so API consumers don't end up depending on the internal numeric codes.
+type ParcelStatus int
+
+const (
+	ParcelStatusRegistered ParcelStatus = iota
+	ParcelStatusSent
+	ParcelStatusDelivered
+)
+
+// String returns the stable token for s.
+func (s ParcelStatus) String() string {
+	switch s {
+	case ParcelStatusRegistered:
+		return "registered"
+	case ParcelStatusSent:
+		return "sent"
+	case ParcelStatusDelivered:
+		return "delivered"
+	default:
+		return fmt.Sprintf("ParcelStatus(%d)", int(s))
+	}
+}
+
+// ParseParcelStatus parses one of the stable string tokens back into a
+// ParcelStatus.
+func ParseParcelStatus(token string) (ParcelStatus, error) {
+	switch token {
+	case "registered":
+		return ParcelStatusRegistered, nil
+	case "sent":
+		return ParcelStatusSent, nil
+	case "delivered":
+		return ParcelStatusDelivered, nil
+	default:
+		return 0, fmt.Errorf("parcel: unknown status %q", token)
+	}
+}
+
+// MarshalJSON renders the status as its stable string token.
+func (s ParcelStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON parses the status from its stable string token.
+func (s *ParcelStatus) UnmarshalJSON(data []byte) error {
+	var token string
+	if err := json.Unmarshal(data, &token); err != nil {
+		return err
+	}
+
+	status, err := ParseParcelStatus(token)
+	if err != nil {
+		return err
+	}
+
+	*s = status
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package parcel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Store is the set of operations a parcel tracker needs from storage.
+// It exists so that decorators (caching, metrics, ...) can wrap a
+// ParcelStore while still satisfying callers that only depend on the
+// interface.
+type Store interface {
+	Add(p Parcel) (int, error)
+	Get(number int) (Parcel, error)
+	GetByClient(client int) ([]Parcel, error)
+	SetStatus(number int, status ParcelStatus) error
+	SetAddress(number int, address string) error
+	Delete(number int) error
+}
+
+// ParcelStore provides CRUD access to parcels backed by a SQLite database.
+type ParcelStore struct {
+	db *sql.DB
+}
+
+// NewParcelStore creates a ParcelStore on top of an already-open database.
+func NewParcelStore(db *sql.DB) ParcelStore {
+	return ParcelStore{db: db}
+}
+
+// Add inserts a new parcel and returns its generated number.
+func (s ParcelStore) Add(p Parcel) (int, error) {
+	var id int64
+
+	err := withRetry(func() error {
+		res, err := s.db.Exec(
+			"INSERT INTO parcel (client, status, address, created_at) VALUES (:client, :status, :address, :created_at)",
+			sql.Named("client", p.Client),
+			sql.Named("status", int(p.Status)),
+			sql.Named("address", p.Address),
+			sql.Named("created_at", p.CreatedAt.UTC().Format(time.RFC3339)),
+		)
+		if err != nil {
+			return err
+		}
+
+		id, err = res.LastInsertId()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// Get returns the parcel with the given number.
+func (s ParcelStore) Get(number int) (Parcel, error) {
+	row := s.db.QueryRow(
+		"SELECT number, client, status, address, created_at FROM parcel WHERE number = :number",
+		sql.Named("number", number),
+	)
+
+	return scanParcel(row)
+}
+
+// GetByClient returns every parcel belonging to the given client.
+func (s ParcelStore) GetByClient(client int) ([]Parcel, error) {
+	rows, err := s.db.Query(
+		"SELECT number, client, status, address, created_at FROM parcel WHERE client = :client",
+		sql.Named("client", client),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parcels []Parcel
+	for rows.Next() {
+		p, err := scanParcel(rows)
+		if err != nil {
+			return nil, err
+		}
+		parcels = append(parcels, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return parcels, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanParcel(row rowScanner) (Parcel, error) {
+	var (
+		p         Parcel
+		status    int
+		createdAt string
+	)
+
+	if err := row.Scan(&p.Number, &p.Client, &status, &p.Address, &createdAt); err != nil {
+		return Parcel{}, err
+	}
+
+	parsed, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return Parcel{}, err
+	}
+
+	p.Status = ParcelStatus(status)
+	p.CreatedAt = parsed
+
+	return p, nil
+}
+
+// SetStatus updates the status of a parcel.
+func (s ParcelStore) SetStatus(number int, status ParcelStatus) error {
+	return withRetry(func() error {
+		_, err := s.db.Exec(
+			"UPDATE parcel SET status = :status WHERE number = :number",
+			sql.Named("status", int(status)),
+			sql.Named("number", number),
+		)
+		return err
+	})
+}
+
+// SetAddress updates the address of a parcel that is still registered.
+func (s ParcelStore) SetAddress(number int, address string) error {
+	return withRetry(func() error {
+		_, err := s.db.Exec(
+			"UPDATE parcel SET address = :address WHERE number = :number AND status = :status",
+			sql.Named("address", address),
+			sql.Named("number", number),
+			sql.Named("status", int(ParcelStatusRegistered)),
+		)
+		return err
+	})
+}
+
+// Delete removes a parcel that is still registered.
+func (s ParcelStore) Delete(number int) error {
+	return withRetry(func() error {
+		_, err := s.db.Exec(
+			"DELETE FROM parcel WHERE number = :number AND status = :status",
+			sql.Named("number", number),
+			sql.Named("status", int(ParcelStatusRegistered)),
+		)
+		return err
+	})
+}
+
+var _ Store = ParcelStore{}
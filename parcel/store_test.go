@@ -1,12 +1,15 @@
-package main
+package parcel_test
 
 import (
-	"database/sql"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 	"math/rand"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+	"github.com/creamlaflare/go-db-sql-final/testhelpers"
 )
 
 var (
@@ -18,31 +21,32 @@ var (
 )
 
 // getTestParcel returns a test parcel.
-func getTestParcel() Parcel {
-	return Parcel{
+func getTestParcel() parcel.Parcel {
+	return parcel.Parcel{
 		Client:    1000,
-		Status:    ParcelStatusRegistered,
+		Status:    parcel.ParcelStatusRegistered,
 		Address:   "test",
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
 	}
 }
 
 // TestAddGetDelete tests adding, retrieving, and deleting a parcel.
 func TestAddGetDelete(t *testing.T) {
+	t.Parallel()
+
 	// prepare
-	db, err := sql.Open("sqlite", "tracker.db")
-	require.NoError(t, err)
-	defer db.Close()
-	store := NewParcelStore(db)
-	parcel := getTestParcel()
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
+	p := getTestParcel()
 
-	id, err := store.Add(parcel)
+	id, err := store.Add(p)
 	assert.NoError(t, err)
-	assert.Equal(t, id, parcel.Number)
+	p.Number = id // Update the identifier of the added parcel.
 
 	storedParcel, err := store.Get(id)
 	assert.NoError(t, err)
-	assert.Equal(t, parcel, storedParcel)
+	assert.Equal(t, p, storedParcel)
 
 	err = store.Delete(id)
 	assert.NoError(t, err)
@@ -50,16 +54,17 @@ func TestAddGetDelete(t *testing.T) {
 
 // TestSetAddress tests updating the address.
 func TestSetAddress(t *testing.T) {
+	t.Parallel()
+
 	// prepare
-	db, err := sql.Open("sqlite", "tracker.db")
-	require.NoError(t, err)
-	defer db.Close()
-	store := NewParcelStore(db)
-	parcel := getTestParcel()
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
+	p := getTestParcel()
 
-	id, err := store.Add(parcel)
+	id, err := store.Add(p)
 	assert.NoError(t, err)
-	assert.Equal(t, id, parcel.Number)
+	p.Number = id // Update the identifier of the added parcel.
 
 	newAddress := "new test address"
 	err = store.SetAddress(id, newAddress)
@@ -75,25 +80,26 @@ func TestSetAddress(t *testing.T) {
 
 // TestSetStatus tests updating the status.
 func TestSetStatus(t *testing.T) {
+	t.Parallel()
+
 	// prepare
-	db, err := sql.Open("sqlite", "tracker.db")
-	require.NoError(t, err)
-	defer db.Close()
-	store := NewParcelStore(db)
-	parcel := getTestParcel()
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
+	p := getTestParcel()
 
-	id, err := store.Add(parcel)
+	id, err := store.Add(p)
 	assert.NoError(t, err)
-	assert.Equal(t, id, parcel.Number)
+	p.Number = id // Update the identifier of the added parcel.
 
-	err = store.SetStatus(id, ParcelStatusDelivered)
+	err = store.SetStatus(id, parcel.ParcelStatusDelivered)
 	assert.NoError(t, err)
 
 	storedParcel, err := store.Get(id)
 	assert.NoError(t, err)
-	assert.Equal(t, ParcelStatusDelivered, storedParcel.Status)
+	assert.Equal(t, parcel.ParcelStatusDelivered, storedParcel.Status)
 
-	err = store.SetStatus(id, ParcelStatusRegistered)
+	err = store.SetStatus(id, parcel.ParcelStatusRegistered)
 	assert.NoError(t, err)
 
 	err = store.Delete(id)
@@ -102,18 +108,19 @@ func TestSetStatus(t *testing.T) {
 
 // TestGetByClient tests retrieving parcels by client identifier.
 func TestGetByClient(t *testing.T) {
+	t.Parallel()
+
 	// prepare
-	db, err := sql.Open("sqlite", "tracker.db")
-	require.NoError(t, err)
-	defer db.Close()
-	store := NewParcelStore(db)
+	db, cleanup := testhelpers.SetupTestDB(t)
+	defer cleanup()
+	store := parcel.NewParcelStore(db)
 
-	parcels := []Parcel{
+	parcels := []parcel.Parcel{
 		getTestParcel(),
 		getTestParcel(),
 		getTestParcel(),
 	}
-	parcelMap := map[int]Parcel{}
+	parcelMap := map[int]parcel.Parcel{}
 
 	client := randRange.Intn(10_000_000)
 	parcels[0].Client = client
@@ -127,7 +134,6 @@ func TestGetByClient(t *testing.T) {
 	for i := 0; i < len(parcels); i++ {
 		id, err := store.Add(parcels[i])
 		assert.NoError(t, err)
-		assert.Equal(t, id, parcels[i].Number)
 
 		// Update the identifier of the added parcel.
 		parcels[i].Number = id
@@ -139,13 +145,13 @@ func TestGetByClient(t *testing.T) {
 	storedParcels, err := store.GetByClient(client)
 	assert.NoError(t, err)
 	assert.Len(t, storedParcels, len(parcels))
-	for _, parcel := range storedParcels {
+	for _, p := range storedParcels {
 		// In parcelMap lie the added parcels, the key - parcel identifier, the value - the parcel itself.
-		require.Contains(t, parcelMap, parcel.Number)
+		require.Contains(t, parcelMap, p.Number)
 	}
 
-	for _, parcel := range storedParcels {
-		err = store.Delete(parcel.Number)
+	for _, p := range storedParcels {
+		err = store.Delete(p.Number)
 		assert.NoError(t, err)
 	}
 }
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+)
+
+// handleParcels serves POST /parcels (create) and GET /parcels?client=N
+// (list by client).
+func (s *Server) handleParcels(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.createParcel(w, r)
+	case http.MethodGet:
+		s.listParcelsByClient(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// handleParcel serves /parcels/{number}, /parcels/{number}/address and
+// /parcels/{number}/status.
+func (s *Server) handleParcel(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/parcels/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	number, err := strconv.Atoi(parts[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("invalid parcel number"))
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.getParcel(w, number)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.deleteParcel(w, number)
+	case len(parts) == 2 && parts[1] == "address" && r.Method == http.MethodPut:
+		s.setParcelAddress(w, r, number)
+	case len(parts) == 2 && parts[1] == "status" && r.Method == http.MethodPut:
+		s.setParcelStatus(w, r, number)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+func (s *Server) createParcel(w http.ResponseWriter, r *http.Request) {
+	var p parcel.Parcel
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	number, err := s.store.Add(p)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	p.Number = number
+
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) getParcel(w http.ResponseWriter, number int) {
+	p, err := s.store.Get(number)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) listParcelsByClient(w http.ResponseWriter, r *http.Request) {
+	clientParam := r.URL.Query().Get("client")
+	if clientParam == "" {
+		writeError(w, http.StatusBadRequest, errors.New("client query parameter is required"))
+		return
+	}
+
+	client, err := strconv.Atoi(clientParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("invalid client"))
+		return
+	}
+
+	parcels, err := s.store.GetByClient(client)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, parcels)
+}
+
+func (s *Server) setParcelAddress(w http.ResponseWriter, r *http.Request, number int) {
+	var body struct {
+		Address string `json:"address"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.store.SetAddress(number, body.Address); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setParcelStatus(w http.ResponseWriter, r *http.Request, number int) {
+	var body struct {
+		Status parcel.ParcelStatus `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.store.SetStatus(number, body.Status); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteParcel(w http.ResponseWriter, number int) {
+	if err := s.store.Delete(number); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
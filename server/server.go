@@ -0,0 +1,94 @@
+// Package server exposes a parcel.Store over HTTP+JSON so the tracker can be
+// embedded in service-oriented deployments rather than only used in-process.
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+)
+
+// Server serves the parcel tracker API over HTTP.
+type Server struct {
+	store    parcel.Store
+	logger   *log.Logger
+	listener net.Listener
+	http     *http.Server
+}
+
+// Option customizes a Server at construction time.
+type Option func(*Server)
+
+// WithLogger overrides the logger used for request logging. The default
+// logs to log.Default().
+func WithLogger(logger *log.Logger) Option {
+	return func(s *Server) { s.logger = logger }
+}
+
+// New binds addr and builds a Server backed by store, ready for Run. Binding
+// up front means Addr reflects the real port even when addr ends in ":0".
+func New(addr string, store parcel.Store, opts ...Option) (*Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		store:    store,
+		logger:   log.Default(),
+		listener: ln,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.routes(mux)
+
+	s.http = &http.Server{
+		Handler:           withLogging(s.logger)(mux),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return s, nil
+}
+
+func (s *Server) routes(mux *http.ServeMux) {
+	mux.HandleFunc("/parcels", s.handleParcels)
+	mux.HandleFunc("/parcels/", s.handleParcel)
+}
+
+// Run serves on the listener bound by New and blocks until the context is
+// canceled, at which point it shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.http.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.http.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		return <-errCh
+	}
+}
+
+// Addr returns the address the server is actually listening on, which
+// matters when New was called with a ":0" port and the OS assigned one.
+func (s *Server) Addr() *net.TCPAddr {
+	return s.listener.Addr().(*net.TCPAddr)
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+	"github.com/creamlaflare/go-db-sql-final/testhelpers"
+)
+
+// startTestServer boots a Server against a temp SQLite file and returns its
+// base URL plus a func to shut it down.
+func startTestServer(t *testing.T) string {
+	t.Helper()
+
+	db, cleanup := testhelpers.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	store := parcel.NewParcelStore(db)
+	srv, err := New("127.0.0.1:0", store)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	return "http://" + srv.Addr().String()
+}
+
+func TestServerEndpoints(t *testing.T) {
+	t.Parallel()
+
+	baseURL := startTestServer(t)
+
+	// Add
+	body, _ := json.Marshal(parcel.Parcel{
+		Client:    42,
+		Status:    parcel.ParcelStatusRegistered,
+		Address:   "first address",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	})
+	resp, err := http.Post(baseURL+"/parcels", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created parcel.Parcel
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.NotZero(t, created.Number)
+
+	// Get
+	resp, err = http.Get(fmt.Sprintf("%s/parcels/%d", baseURL, created.Number))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// GetByClient
+	resp, err = http.Get(fmt.Sprintf("%s/parcels?client=%d", baseURL, created.Client))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var listed []parcel.Parcel
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	assert.Len(t, listed, 1)
+
+	// SetAddress
+	addrBody, _ := json.Marshal(map[string]string{"address": "second address"})
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/parcels/%d/address", baseURL, created.Number), bytes.NewReader(addrBody))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// SetStatus
+	statusBody, _ := json.Marshal(map[string]parcel.ParcelStatus{"status": parcel.ParcelStatusSent})
+	req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("%s/parcels/%d/status", baseURL, created.Number), bytes.NewReader(statusBody))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Delete a second, still-registered parcel (delivered ones can't be deleted).
+	body, _ = json.Marshal(parcel.Parcel{
+		Client:    42,
+		Status:    parcel.ParcelStatusRegistered,
+		Address:   "disposable",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	})
+	resp, err = http.Post(baseURL+"/parcels", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var disposable parcel.Parcel
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&disposable))
+
+	req, err = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/parcels/%d", baseURL, disposable.Number), nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestServerGetUnknownParcel(t *testing.T) {
+	t.Parallel()
+
+	baseURL := startTestServer(t)
+
+	resp, err := http.Get(baseURL + "/parcels/999999")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,32 @@
+// Package testhelpers provides shared test setup so that tests across the
+// module don't each reimplement hermetic, parallel-safe database setup.
+package testhelpers
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/creamlaflare/go-db-sql-final/parcel"
+
+	_ "modernc.org/sqlite"
+)
+
+// SetupTestDB opens a fresh, migrated SQLite database under t.TempDir() and
+// returns it along with a cleanup func that closes it. Each call gets its
+// own file, so tests using it are hermetic and safe to run with
+// t.Parallel().
+func SetupTestDB(t *testing.T) (*sql.DB, func()) {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "tracker.db")
+
+	db, err := parcel.Open(dbPath)
+	require.NoError(t, err)
+
+	require.NoError(t, parcel.Migrate(db))
+
+	return db, func() { db.Close() }
+}